@@ -0,0 +1,164 @@
+package godbc
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Snapshot holds a value captured at function entry, for comparison
+// against its post-call value in a postcondition. See Capture and
+// CaptureDeep.
+type Snapshot struct {
+	v interface{}
+}
+
+// Capture evaluates fn immediately and returns a *Snapshot memoizing its
+// result, so a later Ensure call can refer to the pre-call ("old")
+// value:
+//
+//	snap := godbc.Capture(func() interface{} { return obj.Count })
+//	obj.Count++
+//	godbc.Ensure(obj.Count == snap.Int()+1)
+//
+// Capture does nothing and returns an empty *Snapshot when contracts are
+// compiled out (see Enabled), so it is safe to call unconditionally.
+func Capture(fn func() interface{}) *Snapshot {
+	if !Enabled() {
+		return &Snapshot{}
+	}
+	return &Snapshot{v: fn()}
+}
+
+// CaptureDeep clones the exported fields of structPtr (a pointer to a
+// struct) via reflection, so a postcondition can compare the pre- and
+// post-state of an aggregate object without it having changed out from
+// under the snapshot:
+//
+//	snap := godbc.CaptureDeep(obj)
+//	obj.Mutate()
+//	godbc.Ensure(!reflect.DeepEqual(snap.Value(), obj))
+//
+// Unexported fields are left at their zero value in the clone; there is
+// no portable way to copy them. A cyclic structure makes a deep clone
+// ill-defined, so CaptureDeep returns a *Snapshot whose Value() is an
+// error describing where the cycle was found, rather than looping
+// forever; a nil structPtr reports the same kind of error rather than
+// panicking. CaptureDeep does nothing and returns an empty *Snapshot
+// when contracts are compiled out (see Enabled).
+func CaptureDeep(structPtr interface{}) *Snapshot {
+	if !Enabled() {
+		return &Snapshot{}
+	}
+	rv := reflect.ValueOf(structPtr)
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		rv = reflect.Value{} // a typed nil pointer is reported like an untyped nil
+	}
+	cloned, err := deepCloneExported(rv, map[uintptr]bool{})
+	if err != nil {
+		return &Snapshot{v: err}
+	}
+	return &Snapshot{v: cloned.Interface()}
+}
+
+func deepCloneExported(v reflect.Value, seen map[uintptr]bool) (reflect.Value, error) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return reflect.Value{}, fmt.Errorf("godbc: CaptureDeep: expected a pointer to a struct, got nil")
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v, nil
+		}
+		addr := v.Pointer()
+		if seen[addr] {
+			return reflect.Value{}, fmt.Errorf("godbc: CaptureDeep: cyclic reference at %s", v.Type())
+		}
+		seen[addr] = true
+		elem, err := deepCloneExported(v.Elem(), seen)
+		delete(seen, addr) // only ancestors on the current path count as a cycle
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out := reflect.New(v.Elem().Type())
+		out.Elem().Set(elem)
+		return out, nil
+
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" {
+				continue // unexported: left zero-valued
+			}
+			cloned, err := deepCloneExported(v.Field(i), seen)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.Field(i).Set(cloned)
+		}
+		return out, nil
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v, nil
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			cloned, err := deepCloneExported(v.Index(i), seen)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.Index(i).Set(cloned)
+		}
+		return out, nil
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v, nil
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			cloned, err := deepCloneExported(iter.Value(), seen)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.SetMapIndex(iter.Key(), cloned)
+		}
+		return out, nil
+
+	default:
+		return v, nil
+	}
+}
+
+// Value returns the raw captured value.
+func (s *Snapshot) Value() interface{} {
+	return s.v
+}
+
+// Int returns the captured value as an int, or 0 if it wasn't one.
+func (s *Snapshot) Int() int {
+	i, _ := s.v.(int)
+	return i
+}
+
+// String returns the captured value as a string, or "" if it wasn't
+// one.
+func (s *Snapshot) String() string {
+	str, _ := s.v.(string)
+	return str
+}
+
+// Bool returns the captured value as a bool, or false if it wasn't one.
+func (s *Snapshot) Bool() bool {
+	b, _ := s.v.(bool)
+	return b
+}
+
+// Diff renders snap's captured ("old") value next to current ("new"),
+// for inclusion in an Ensure message on failure:
+//
+//	godbc.Ensure(obj.Count == snap.Int()+1, godbc.Diff(snap, obj.Count))
+func Diff(snap *Snapshot, current interface{}) string {
+	return fmt.Sprintf("old=%+v new=%+v", snap.Value(), current)
+}
@@ -0,0 +1,24 @@
+//go:build godbc_disable
+
+package godbc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptureSkippedWhenDisabled(t *testing.T) {
+	called := false
+	snap := Capture(func() interface{} {
+		called = true
+		return 42
+	})
+	assert.False(t, called, "Capture must not evaluate fn in a godbc_disable build")
+	assert.Nil(t, snap.Value())
+}
+
+func TestCaptureDeepSkippedWhenDisabled(t *testing.T) {
+	snap := CaptureDeep(&struct{ Balance int }{Balance: 1})
+	assert.Nil(t, snap.Value())
+}
@@ -0,0 +1,125 @@
+//go:build !godbc_disable
+
+package godbc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptureValue(t *testing.T) {
+	n := 5
+	snap := Capture(func() interface{} { return n })
+	n = 6
+	assert.Equal(t, 5, snap.Int())
+}
+
+func TestCapturePointer(t *testing.T) {
+	d := &Date{day: 1, month: 1}
+	snap := Capture(func() interface{} { return *d })
+	d.Set(2, 2)
+
+	old := snap.Value().(Date)
+	assert.Equal(t, 1, old.day)
+	assert.Equal(t, 2, d.day)
+}
+
+func TestCaptureSlice(t *testing.T) {
+	s := []int{1, 2, 3}
+	snap := Capture(func() interface{} { return append([]int(nil), s...) })
+	s[0] = 99
+	assert.Equal(t, []int{1, 2, 3}, snap.Value())
+}
+
+func TestCaptureMap(t *testing.T) {
+	m := map[string]int{"a": 1}
+	snap := Capture(func() interface{} {
+		clone := make(map[string]int, len(m))
+		for k, v := range m {
+			clone[k] = v
+		}
+		return clone
+	})
+	m["a"] = 2
+	assert.Equal(t, map[string]int{"a": 1}, snap.Value())
+}
+
+type account struct {
+	Balance int
+	Owners  []string
+	Notes   map[string]string
+	secret  string
+}
+
+func TestCaptureDeep(t *testing.T) {
+	a := &account{
+		Balance: 10,
+		Owners:  []string{"alice"},
+		Notes:   map[string]string{"k": "v"},
+		secret:  "hidden",
+	}
+	snap := CaptureDeep(a)
+
+	a.Balance = 20
+	a.Owners[0] = "bob"
+	a.Notes["k"] = "changed"
+
+	old := snap.Value().(*account)
+	assert.Equal(t, 10, old.Balance)
+	assert.Equal(t, []string{"alice"}, old.Owners)
+	assert.Equal(t, "v", old.Notes["k"])
+	assert.Equal(t, "", old.secret, "unexported fields are left zero-valued")
+}
+
+type cyclic struct {
+	Next *cyclic
+}
+
+func TestCaptureDeepCyclic(t *testing.T) {
+	a := &cyclic{}
+	a.Next = a
+
+	snap := CaptureDeep(a)
+	err, ok := snap.Value().(error)
+	if assert.True(t, ok, "expected CaptureDeep to report a cyclic reference as an error") {
+		assert.Contains(t, err.Error(), "cyclic reference")
+	}
+}
+
+func TestCaptureDeepNil(t *testing.T) {
+	snap := CaptureDeep(nil)
+	err, ok := snap.Value().(error)
+	if assert.True(t, ok, "expected CaptureDeep to report a nil structPtr as an error") {
+		assert.Contains(t, err.Error(), "CaptureDeep")
+	}
+}
+
+type leaf struct {
+	Value int
+}
+
+type diamond struct {
+	A *leaf
+	B *leaf
+}
+
+func TestCaptureDeepSharedPointerNotCyclic(t *testing.T) {
+	shared := &leaf{Value: 1}
+	d := &diamond{A: shared, B: shared}
+
+	snap := CaptureDeep(d)
+	old, ok := snap.Value().(*diamond)
+	if !assert.True(t, ok, "expected CaptureDeep to clone a DAG with a shared pointer, not report a cycle") {
+		return
+	}
+
+	shared.Value = 2
+	assert.Equal(t, 1, old.A.Value)
+	assert.Equal(t, 1, old.B.Value)
+}
+
+func TestDiff(t *testing.T) {
+	snap := Capture(func() interface{} { return 1 })
+	assert.Equal(t, "old=1 new=2", Diff(snap, 2))
+}
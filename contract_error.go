@@ -0,0 +1,122 @@
+package godbc
+
+import "fmt"
+
+// Kind identifies which category of contract was violated.
+type Kind int
+
+const (
+	// KindRequire marks a violated precondition (Require).
+	KindRequire Kind = iota
+	// KindEnsure marks a violated postcondition (Ensure).
+	KindEnsure
+	// KindCheck marks a failed simple assertion (Check).
+	KindCheck
+	// KindInvariant marks a violated object invariant (Invariant,
+	// InvariantSimple).
+	KindInvariant
+)
+
+// String returns the same label dbc_panic has always used, e.g. "REQUIRE".
+func (k Kind) String() string {
+	switch k {
+	case KindRequire:
+		return "REQUIRE"
+	case KindEnsure:
+		return "ENSURE"
+	case KindCheck:
+		return "CHECK"
+	case KindInvariant:
+		return "INVARIANT"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ContractError describes a single contract violation. It is the value
+// godbc panics with (or hands to a handler installed with SetHandler)
+// whenever a Require, Ensure, Check or Invariant call fails.
+type ContractError struct {
+	Kind    Kind
+	Func    string
+	File    string
+	Line    int
+	PC      uintptr
+	Message []interface{}
+
+	// Object is the value that was tested by Invariant/InvariantSimple.
+	// It is nil for Require, Ensure and Check.
+	Object interface{}
+}
+
+// Error renders the violation the same way dbc_panic has always
+// formatted it, including the tested object (if any) in the Info line
+// alongside the caller's message, the same way Logger.Log's message
+// slice is built.
+func (e *ContractError) Error() string {
+	s := fmt.Sprintf("%s:\n\r\tfunc (%s) 0x%x\n\r\tFile %s:%d",
+		e.Kind, e.Func, e.PC, e.File, e.Line)
+	info := e.Message
+	if e.Object != nil {
+		info = append(append([]interface{}{}, e.Message...), e.Object)
+	}
+	if len(info) > 0 {
+		s += fmt.Sprintf("\n\r\tInfo: %+v", info)
+	}
+	return s
+}
+
+// Unwrap always returns nil: a ContractError has no underlying cause, it
+// is itself the root cause.
+func (e *ContractError) Unwrap() error {
+	return nil
+}
+
+// Is reports whether target is a *ContractError of the same Kind, so
+// callers can write errors.Is(err, &godbc.ContractError{Kind: godbc.KindRequire}).
+func (e *ContractError) Is(target error) bool {
+	t, ok := target.(*ContractError)
+	if !ok {
+		return false
+	}
+	return e.Kind == t.Kind
+}
+
+// handler, when non-nil, is invoked instead of panicking whenever a
+// contract is violated. Install one with SetHandler.
+var handler func(*ContractError)
+
+// SetHandler installs fn to run whenever a contract is violated, in
+// place of the default behavior of panicking with the *ContractError.
+// Passing nil restores the default panic behavior. This lets a program
+// redirect violations to a logger or test reporter, e.g. from an HTTP
+// handler's recovery middleware or a gRPC interceptor.
+func SetHandler(fn func(*ContractError)) {
+	handler = fn
+}
+
+// Recover converts a panic raised by a contract violation back into a
+// plain error, for deferred, error-returning wrappers around
+// contract-checked code:
+//
+//	func Foo() (err error) {
+//		defer godbc.Recover(&err)
+//		godbc.Require(...)
+//		...
+//	}
+//
+// Recover must be deferred directly (as above), since recover only has
+// an effect when called directly by a deferred function; wrapping it in
+// another closure first will not catch the panic. Any recovered value
+// that is not a *ContractError is re-panicked unchanged.
+func Recover(errp *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if ce, ok := r.(*ContractError); ok {
+		*errp = ce
+		return
+	}
+	panic(r)
+}
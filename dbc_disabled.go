@@ -0,0 +1,102 @@
+//go:build godbc_disable
+
+package godbc
+
+import (
+	"os"
+	"runtime"
+)
+
+// Enabled reports whether contract checks are compiled into this binary.
+//
+// It is a constant-returning helper so that callers can skip building
+// expensive contract expressions when contracts are not compiled in, e.g.
+//
+//	if godbc.Enabled() {
+//		godbc.Require(expensiveCheck())
+//	}
+func Enabled() bool {
+	return false
+}
+
+// requireOn, ensureOn and invariantOn let a binary built with the
+// godbc_disable tag selectively re-enable one category of contract at a
+// time (e.g. only postconditions) without recompiling, by setting the
+// GODBC_REQUIRE, GODBC_ENSURE or GODBC_INVARIANT environment variables to
+// any non-empty value.
+var (
+	requireOn   = os.Getenv("GODBC_REQUIRE") != ""
+	ensureOn    = os.Getenv("GODBC_ENSURE") != ""
+	invariantOn = os.Getenv("GODBC_INVARIANT") != ""
+)
+
+// dbc_panic_fallback is a trimmed down copy of the enabled build's
+// dbc_panic, kept here so a disabled build can still honor the
+// GODBC_REQUIRE/GODBC_ENSURE/GODBC_INVARIANT toggles without pulling in
+// the always-on code path.
+func dbc_panic_fallback(kind Kind, b bool, obj interface{}, message ...interface{}) {
+	if !b {
+		pc, file, line, _ := runtime.Caller(2)
+		caller_func_info := runtime.FuncForPC(pc)
+
+		err := &ContractError{
+			Kind:    kind,
+			Func:    caller_func_info.Name(),
+			File:    file,
+			Line:    line,
+			PC:      pc,
+			Message: message,
+			Object:  obj,
+		}
+
+		if logger != nil {
+			logMessage := message
+			if obj != nil {
+				logMessage = append(append([]interface{}{}, message...), obj)
+			}
+			logger.Log(kind, runtime.Frame{PC: pc, Func: caller_func_info, Function: caller_func_info.Name(), File: file, Line: line}, logMessage)
+		}
+
+		if handler != nil {
+			handler(err)
+			return
+		}
+
+		panic(err)
+	}
+}
+
+// Require is a no-op unless GODBC_REQUIRE is set, since this binary was
+// built with the godbc_disable tag.
+func Require(b bool, message ...interface{}) {
+	if requireOn {
+		dbc_panic_fallback(KindRequire, b, nil, message...)
+	}
+}
+
+// Ensure is a no-op unless GODBC_ENSURE is set, since this binary was
+// built with the godbc_disable tag.
+func Ensure(b bool, message ...interface{}) {
+	if ensureOn {
+		dbc_panic_fallback(KindEnsure, b, nil, message...)
+	}
+}
+
+// Check is always a no-op in a godbc_disable build.
+func Check(b bool, message ...interface{}) {}
+
+// InvariantSimple is a no-op unless GODBC_INVARIANT is set, since this
+// binary was built with the godbc_disable tag.
+func InvariantSimple(obj InvariantSimpleTester, message ...interface{}) {
+	if invariantOn {
+		dbc_panic_fallback(KindInvariant, obj.Invariant(), obj, message...)
+	}
+}
+
+// Invariant is a no-op unless GODBC_INVARIANT is set, since this binary
+// was built with the godbc_disable tag.
+func Invariant(obj InvariantTester, message ...interface{}) {
+	if invariantOn {
+		dbc_panic_fallback(KindInvariant, obj.Invariant(), obj, message...)
+	}
+}
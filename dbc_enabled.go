@@ -0,0 +1,113 @@
+//go:build !godbc_disable
+
+package godbc
+
+import (
+	"runtime"
+)
+
+// Enabled reports whether contract checks are compiled into this binary.
+//
+// It is a constant-returning helper so that callers can skip building
+// expensive contract expressions when contracts are not compiled in, e.g.
+//
+//	if godbc.Enabled() {
+//		godbc.Require(expensiveCheck())
+//	}
+func Enabled() bool {
+	return true
+}
+
+// dbc_panic builds a *ContractError describing the violation and either
+// hands it to the installed handler (see SetHandler) or panics with it.
+func dbc_panic(kind Kind, b bool, obj interface{}, message ...interface{}) {
+	if !b {
+
+		// Get caller information which is the caller
+		// of the caller of this function
+		pc, file, line, _ := runtime.Caller(2)
+		caller_func_info := runtime.FuncForPC(pc)
+
+		err := &ContractError{
+			Kind:    kind,
+			Func:    caller_func_info.Name(),
+			File:    file,
+			Line:    line,
+			PC:      pc,
+			Message: message,
+			Object:  obj,
+		}
+
+		if logger != nil {
+			logMessage := message
+			if obj != nil {
+				logMessage = append(append([]interface{}{}, message...), obj)
+			}
+			logger.Log(kind, runtime.Frame{PC: pc, Func: caller_func_info, Function: caller_func_info.Name(), File: file, Line: line}, logMessage)
+		}
+
+		if handler != nil {
+			handler(err)
+			return
+		}
+
+		// Finally panic
+		panic(err)
+	}
+}
+
+// Require checks that the preconditions are satisfied before
+// executing the function
+//
+// Example
+//
+//	func Divide(a, b int) int {
+//		godbc.Require(b != 0)
+//		return a/b
+//	}
+func Require(b bool, message ...interface{}) {
+	dbc_panic(KindRequire, b, nil, message...)
+}
+
+// Ensure checks the postconditions are satisfied before returning
+// to the caller.
+//
+// Example Code
+//
+//	type Data struct {
+//		a int
+//	}
+//
+//	func (*d Data) Set(a int) {
+//		d.a = a
+//		godbc.Ensure(d.a == a)
+//	}
+func Ensure(b bool, message ...interface{}) {
+	dbc_panic(KindEnsure, b, nil, message...)
+}
+
+// Check provides a simple assert
+func Check(b bool, message ...interface{}) {
+	dbc_panic(KindCheck, b, nil, message...)
+}
+
+// InvariantSimple calls the objects Invariant() receiver to test
+// the object for correctness.
+//
+// The caller object must provide an object that supports the
+// interface InvariantSimpleTester and does not need to provide
+// a String() receiver
+func InvariantSimple(obj InvariantSimpleTester, message ...interface{}) {
+	dbc_panic(KindInvariant, obj.Invariant(), obj, message...)
+}
+
+// Invariant calls the objects Invariant() receiver to test
+// the object for correctness.
+//
+// The caller object must provide an object that supports the
+// interface InvariantTester
+//
+// To see an example, please take a look at the godbc_test.go
+func Invariant(obj InvariantTester, message ...interface{}) {
+	dbc_panic(KindInvariant, obj.Invariant(), obj, message...)
+}
@@ -0,0 +1,147 @@
+//go:build !godbc_disable
+
+package godbc
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Date is a sample type used to demonstrate and exercise the
+// InvariantTester interface.
+type Date struct {
+	day, month int
+}
+
+func (d *Date) Invariant() bool {
+	if (1 <= d.day && d.day <= 31) &&
+		(1 <= d.month && d.month <= 12) {
+		return true
+	}
+	return false
+}
+
+func (d *Date) Set(day, month int) {
+	d.day, d.month = day, month
+}
+
+func (d *Date) String() string {
+	return fmt.Sprintf("Day:%d Month:%d",
+		d.day, d.month)
+}
+
+func TestInvariant(t *testing.T) {
+	d := &Date{0, 0}
+	assert.Panics(t, func() {
+		Invariant(d)
+	})
+
+	d.Set(15, 6)
+	assert.NotPanics(t, func() {
+		Invariant(d)
+	})
+}
+
+func TestContractErrorKindAndObject(t *testing.T) {
+	d := &Date{0, 0}
+
+	defer func() {
+		r := recover()
+		ce, ok := r.(*ContractError)
+		if !assert.True(t, ok, "expected a *ContractError, got %T", r) {
+			return
+		}
+		assert.Equal(t, KindInvariant, ce.Kind)
+		assert.Equal(t, d, ce.Object)
+		assert.True(t, errors.Is(ce, &ContractError{Kind: KindInvariant}))
+		assert.False(t, errors.Is(ce, &ContractError{Kind: KindRequire}))
+	}()
+
+	Invariant(d)
+}
+
+func TestContractErrorIncludesObject(t *testing.T) {
+	d := &Date{0, 0}
+
+	defer func() {
+		r := recover()
+		ce, ok := r.(*ContractError)
+		if !assert.True(t, ok, "expected a *ContractError, got %T", r) {
+			return
+		}
+		assert.Contains(t, ce.Error(), "Info:")
+		assert.Contains(t, ce.Error(), d.String())
+	}()
+
+	Invariant(d)
+}
+
+func TestSetHandler(t *testing.T) {
+	var got *ContractError
+	SetHandler(func(ce *ContractError) {
+		got = ce
+	})
+	defer SetHandler(nil)
+
+	assert.NotPanics(t, func() {
+		Require(false, "handled instead of panicking")
+	})
+	if assert.NotNil(t, got) {
+		assert.Equal(t, KindRequire, got.Kind)
+	}
+}
+
+type logCall struct {
+	kind    Kind
+	frame   runtime.Frame
+	message []interface{}
+}
+
+type recordingLogger struct {
+	calls []logCall
+}
+
+func (l *recordingLogger) Log(kind Kind, frame runtime.Frame, message []interface{}) {
+	l.calls = append(l.calls, logCall{kind, frame, message})
+}
+
+func TestSetLogger(t *testing.T) {
+	rl := &recordingLogger{}
+	SetLogger(rl)
+	defer SetLogger(nil)
+
+	d := &Date{0, 0}
+	assert.Panics(t, func() {
+		Invariant(d, "bad date")
+	})
+
+	if assert.Len(t, rl.calls, 1) {
+		call := rl.calls[0]
+		assert.Equal(t, KindInvariant, call.kind)
+		assert.True(t, strings.Contains(call.frame.Function, "TestSetLogger"))
+		if assert.Len(t, call.message, 2) {
+			assert.Equal(t, "bad date", call.message[0])
+			assert.Equal(t, d, call.message[1])
+		}
+	}
+}
+
+func TestRecover(t *testing.T) {
+	fn := func() (err error) {
+		defer Recover(&err)
+		Require(false, "boom")
+		return nil
+	}
+
+	err := fn()
+	if assert.Error(t, err) {
+		var ce *ContractError
+		assert.True(t, errors.As(err, &ce))
+		assert.Equal(t, KindRequire, ce.Kind)
+	}
+}
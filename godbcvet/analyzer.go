@@ -0,0 +1,408 @@
+// Package godbcvet implements a static analyzer that catches common
+// misuse of the godbc contract-checking API that the runtime library
+// itself has no way to detect:
+//
+//   - an exported function in a package whose doc comment carries the
+//     //godbc:checked pragma that doesn't call godbc.Require before its
+//     first non-assignment statement;
+//   - an exported, pointer-receiver mutator method (one that assigns to
+//     a field of its receiver) on a type that implements
+//     godbc.InvariantTester but never defers godbc.Invariant(receiver);
+//   - a godbc.Require/Ensure/Check argument that calls a function with
+//     detectable side effects: the contract function is a no-op in a
+//     godbc_disable build, but its argument is still evaluated there, so
+//     the side effect still runs unless the call site is guarded by
+//     godbc.Enabled().
+//
+// A finding on a given line can be suppressed with a "//godbc:ignore"
+// comment on that line or the line immediately above it.
+//
+// Analyzer is a golang.org/x/tools/go/analysis.Analyzer, so it runs
+// under go vet -vettool, and can be wrapped in a golangci-lint custom
+// linter plugin by exposing Analyzer from a plugin's AnalyzerPlugin.
+package godbcvet
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const godbcPkgPath = "github.com/santosh653/godbc"
+
+// Analyzer flags godbc.Require/Ensure/Check/Invariant misuse.
+var Analyzer = &analysis.Analyzer{
+	Name:     "godbcvet",
+	Doc:      "check for missing or unsound use of the godbc contract API",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	ignored := collectIgnoreLines(pass)
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	if packageIsChecked(pass) {
+		checkRequirePreconditions(pass, ignored)
+	}
+	checkMutatorInvariants(pass, ignored)
+	checkImpureContractArgs(pass, insp, ignored)
+
+	return nil, nil
+}
+
+// reportf reports a diagnostic unless pos falls on a line suppressed by
+// a //godbc:ignore comment.
+func reportf(pass *analysis.Pass, ignored map[ignoreKey]bool, pos token.Pos, format string, args ...interface{}) {
+	if ignored[ignoreKeyFor(pass, pos)] {
+		return
+	}
+	pass.Reportf(pos, format, args...)
+}
+
+type ignoreKey struct {
+	file string
+	line int
+}
+
+func ignoreKeyFor(pass *analysis.Pass, pos token.Pos) ignoreKey {
+	p := pass.Fset.Position(pos)
+	return ignoreKey{p.Filename, p.Line}
+}
+
+// collectIgnoreLines finds every "//godbc:ignore" comment and records
+// both its own line and the line below it (the common case: the
+// directive sits directly above the call it silences) as suppressed.
+func collectIgnoreLines(pass *analysis.Pass) map[ignoreKey]bool {
+	ignored := map[ignoreKey]bool{}
+	for _, f := range pass.Files {
+		for _, cg := range f.Comments {
+			for _, c := range cg.List {
+				if !strings.Contains(c.Text, "godbc:ignore") {
+					continue
+				}
+				p := pass.Fset.Position(c.Pos())
+				ignored[ignoreKey{p.Filename, p.Line}] = true
+				ignored[ignoreKey{p.Filename, p.Line + 1}] = true
+			}
+		}
+	}
+	return ignored
+}
+
+// packageIsChecked reports whether any file in the package carries a
+// "godbc:checked" pragma on its package doc comment.
+func packageIsChecked(pass *analysis.Pass) bool {
+	for _, f := range pass.Files {
+		if f.Doc == nil {
+			continue
+		}
+		for _, c := range f.Doc.List {
+			if strings.Contains(c.Text, "godbc:checked") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isGodbcCall reports whether call invokes godbc.<name>, resolving the
+// package through type information so import aliases and dot-imports
+// don't defeat the check.
+func isGodbcCall(pass *analysis.Pass, call *ast.CallExpr, name string) bool {
+	var ident *ast.Ident
+	switch fun := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	case *ast.Ident:
+		ident = fun
+	default:
+		return false
+	}
+	if ident.Name != name {
+		return false
+	}
+	fn, ok := pass.TypesInfo.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Type().(*types.Signature).Recv() != nil {
+		return false
+	}
+	return fn.Pkg().Path() == godbcPkgPath
+}
+
+// checkRequirePreconditions flags exported functions that don't call
+// godbc.Require before their first non-assignment statement.
+func checkRequirePreconditions(pass *analysis.Pass, ignored map[ignoreKey]bool) {
+	for _, f := range pass.Files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil || fn.Recv != nil || !fn.Name.IsExported() {
+				continue
+			}
+			if hasLeadingRequire(pass, fn.Body) {
+				continue
+			}
+			reportf(pass, ignored, fn.Pos(),
+				"exported func %s is in a //godbc:checked package but has no godbc.Require before its first non-assignment statement", fn.Name.Name)
+		}
+	}
+}
+
+func hasLeadingRequire(pass *analysis.Pass, body *ast.BlockStmt) bool {
+	for _, stmt := range body.List {
+		if isCallStmt(stmt, func(call *ast.CallExpr) bool {
+			return isGodbcCall(pass, call, "Require")
+		}) {
+			return true
+		}
+		if _, ok := stmt.(*ast.AssignStmt); ok {
+			continue
+		}
+		break
+	}
+	return false
+}
+
+func isCallStmt(stmt ast.Stmt, match func(*ast.CallExpr) bool) bool {
+	expr, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+	call, ok := expr.X.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	return match(call)
+}
+
+// checkMutatorInvariants flags exported, pointer-receiver methods on an
+// InvariantTester-implementing type that assign to a field of their
+// receiver but never defer godbc.Invariant(receiver) or
+// godbc.InvariantSimple(receiver).
+func checkMutatorInvariants(pass *analysis.Pass, ignored map[ignoreKey]bool) {
+	for _, f := range pass.Files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil || fn.Recv == nil || !fn.Name.IsExported() {
+				continue
+			}
+			if fn.Name.Name == "Invariant" || fn.Name.Name == "InvariantSimple" || fn.Name.Name == "String" {
+				continue
+			}
+
+			recvName, named := pointerReceiver(pass, fn)
+			if named == nil || recvName == "" || recvName == "_" {
+				continue
+			}
+			if !implementsInvariantTester(named) {
+				continue
+			}
+			if !writesToReceiverField(fn.Body, recvName) {
+				continue
+			}
+			if defersInvariantCall(pass, fn.Body, recvName) {
+				continue
+			}
+			reportf(pass, ignored, fn.Pos(),
+				"%s.%s mutates the receiver but never defers godbc.Invariant(%s)", named.Obj().Name(), fn.Name.Name, recvName)
+		}
+	}
+}
+
+// pointerReceiver returns the receiver's local name and named type, if
+// fn has a pointer receiver on a named type.
+func pointerReceiver(pass *analysis.Pass, fn *ast.FuncDecl) (string, *types.Named) {
+	if fn.Recv == nil || len(fn.Recv.List) != 1 {
+		return "", nil
+	}
+	fnObj, ok := pass.TypesInfo.Defs[fn.Name].(*types.Func)
+	if !ok {
+		return "", nil
+	}
+	sig := fnObj.Type().(*types.Signature)
+	ptr, ok := sig.Recv().Type().(*types.Pointer)
+	if !ok {
+		return "", nil
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok {
+		return "", nil
+	}
+
+	name := ""
+	if len(fn.Recv.List[0].Names) == 1 {
+		name = fn.Recv.List[0].Names[0].Name
+	}
+	return name, named
+}
+
+// implementsInvariantTester reports whether *named has both
+// Invariant() bool and String() string methods, i.e. satisfies
+// godbc.InvariantTester.
+func implementsInvariantTester(named *types.Named) bool {
+	if named == nil {
+		return false
+	}
+	ptr := types.NewPointer(named)
+	return hasMethod(ptr, "Invariant") && hasMethod(ptr, "String")
+}
+
+func hasMethod(t types.Type, name string) bool {
+	mset := types.NewMethodSet(t)
+	return mset.Lookup(nil, name) != nil
+}
+
+// defersInvariantCall reports whether body contains
+// `defer godbc.Invariant(recv, ...)` or
+// `defer godbc.InvariantSimple(recv, ...)`.
+func defersInvariantCall(pass *analysis.Pass, body *ast.BlockStmt, recv string) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		d, ok := n.(*ast.DeferStmt)
+		if !ok {
+			return true
+		}
+		if !isGodbcCall(pass, d.Call, "Invariant") && !isGodbcCall(pass, d.Call, "InvariantSimple") {
+			return true
+		}
+		if len(d.Call.Args) == 0 {
+			return true
+		}
+		if id, ok := d.Call.Args[0].(*ast.Ident); ok && id.Name == recv {
+			found = true
+		}
+		return true
+	})
+	return found
+}
+
+// checkImpureContractArgs flags godbc.Require/Ensure/Check calls whose
+// argument invokes a pointer-receiver method that writes to one of its
+// receiver's fields, unless the call site is guarded by
+// `if godbc.Enabled() { ... }`.
+func checkImpureContractArgs(pass *analysis.Pass, insp *inspector.Inspector, ignored map[ignoreKey]bool) {
+	nodeFilter := []ast.Node{(*ast.CallExpr)(nil)}
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		var which string
+		switch {
+		case isGodbcCall(pass, call, "Require"):
+			which = "Require"
+		case isGodbcCall(pass, call, "Ensure"):
+			which = "Ensure"
+		case isGodbcCall(pass, call, "Check"):
+			which = "Check"
+		default:
+			return
+		}
+		if len(call.Args) == 0 {
+			return
+		}
+		if guardedByEnabled(pass, call) {
+			return
+		}
+		ast.Inspect(call.Args[0], func(n ast.Node) bool {
+			inner, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if fn := impureCallee(pass, inner); fn != "" {
+				reportf(pass, ignored, inner.Pos(),
+					"godbc.%s argument calls %s, which has side effects; the call still runs in a godbc_disable build unless guarded by godbc.Enabled()", which, fn)
+			}
+			return true
+		})
+	})
+}
+
+// impureCallee returns the callee's name if it resolves to a
+// pointer-receiver method whose body (as seen in this package) assigns
+// to one of the receiver's fields. It returns "" for anything it can't
+// prove impure, so the check only ever flags what it can actually see.
+func impureCallee(pass *analysis.Pass, call *ast.CallExpr) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	fnObj, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok {
+		return ""
+	}
+	sig, ok := fnObj.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return ""
+	}
+	if _, isPtr := sig.Recv().Type().(*types.Pointer); !isPtr {
+		return ""
+	}
+	for _, f := range pass.Files {
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv == nil || pass.TypesInfo.Defs[fd.Name] != fnObj.Origin() {
+				continue
+			}
+			recvName, _ := pointerReceiver(pass, fd)
+			if recvName != "" && writesToReceiverField(fd.Body, recvName) {
+				return fnObj.Name()
+			}
+		}
+	}
+	return ""
+}
+
+func writesToReceiverField(body *ast.BlockStmt, recv string) bool {
+	if body == nil {
+		return false
+	}
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok {
+			return true
+		}
+		for _, lhs := range assign.Lhs {
+			sel, ok := lhs.(*ast.SelectorExpr)
+			if !ok {
+				continue
+			}
+			if id, ok := sel.X.(*ast.Ident); ok && id.Name == recv {
+				found = true
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// guardedByEnabled reports whether call sits inside the then-branch of
+// an `if godbc.Enabled() { ... }` statement.
+func guardedByEnabled(pass *analysis.Pass, call *ast.CallExpr) bool {
+	guarded := false
+	for _, f := range pass.Files {
+		if f.Pos() > call.Pos() || f.End() < call.Pos() {
+			continue
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			ifStmt, ok := n.(*ast.IfStmt)
+			if !ok {
+				return true
+			}
+			cond, ok := ifStmt.Cond.(*ast.CallExpr)
+			if !ok || !isGodbcCall(pass, cond, "Enabled") {
+				return true
+			}
+			if ifStmt.Body.Pos() <= call.Pos() && call.Pos() < ifStmt.Body.End() {
+				guarded = true
+			}
+			return true
+		})
+	}
+	return guarded
+}
@@ -0,0 +1,13 @@
+package godbcvet_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/santosh653/godbc/godbcvet"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), godbcvet.Analyzer, "a")
+}
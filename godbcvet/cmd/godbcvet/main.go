@@ -0,0 +1,15 @@
+// Command godbcvet runs the godbcvet analyzer as a standalone go vet
+// tool:
+//
+//	go vet -vettool=$(which godbcvet) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/santosh653/godbc/godbcvet"
+)
+
+func main() {
+	singlechecker.Main(godbcvet.Analyzer)
+}
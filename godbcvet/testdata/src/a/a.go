@@ -0,0 +1,52 @@
+// Package a is godbcvet testdata exercising the missing-Require check.
+//
+//godbc:checked
+package a
+
+import "github.com/santosh653/godbc"
+
+func Divide(x, y int) int { // want `exported func Divide is in a //godbc:checked package but has no godbc.Require before its first non-assignment statement`
+	return x / y
+}
+
+func Good(x, y int) int {
+	godbc.Require(y != 0)
+	return x / y
+}
+
+//godbc:ignore
+func Ignored(x, y int) int {
+	return x / y
+}
+
+type Account struct {
+	balance int
+}
+
+func (a *Account) Invariant() bool { return a.balance >= 0 }
+func (a *Account) String() string  { return "Account" }
+
+func (a *Account) Withdraw(n int) { // want `Account.Withdraw mutates the receiver but never defers godbc.Invariant\(a\)`
+	a.balance -= n
+}
+
+func (a *Account) Deposit(n int) {
+	defer godbc.Invariant(a)
+	a.balance += n
+}
+
+func (a *Account) bump(n int) bool {
+	a.balance += n
+	return a.balance > 0
+}
+
+func PurityBad(a *Account, n int) {
+	godbc.Require(a.balance > 0) // ok, reads a field, does not call a side-effecting method
+	godbc.Ensure(a.bump(n))      // want `godbc\.Ensure argument calls bump, which has side effects; the call still runs in a godbc_disable build unless guarded by godbc\.Enabled\(\)`
+}
+
+func PurityGuarded(a *Account, n int) { // want `exported func PurityGuarded is in a //godbc:checked package but has no godbc.Require before its first non-assignment statement`
+	if godbc.Enabled() {
+		godbc.Ensure(a.bump(n))
+	}
+}
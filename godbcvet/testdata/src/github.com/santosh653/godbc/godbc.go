@@ -0,0 +1,22 @@
+// Package godbc is a minimal stand-in for github.com/santosh653/godbc,
+// just enough for the godbcvet analyzer's own tests to type-check
+// against without depending on the real module.
+package godbc
+
+type InvariantSimpleTester interface {
+	Invariant() bool
+}
+
+type InvariantTester interface {
+	InvariantSimpleTester
+	String() string
+}
+
+func Enabled() bool { return true }
+
+func Require(b bool, message ...interface{}) {}
+func Ensure(b bool, message ...interface{})  {}
+func Check(b bool, message ...interface{})   {}
+
+func InvariantSimple(obj InvariantSimpleTester, message ...interface{}) {}
+func Invariant(obj InvariantTester, message ...interface{})             {}
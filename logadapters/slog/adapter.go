@@ -0,0 +1,44 @@
+// Package slog adapts godbc.Logger to log/slog, so contract violations
+// are emitted as one structured record instead of only appearing in the
+// panic message.
+package slog
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+
+	"github.com/santosh653/godbc"
+)
+
+// Adapter implements godbc.Logger by writing one slog record per
+// contract violation.
+type Adapter struct {
+	Logger *slog.Logger
+}
+
+// New returns a godbc.Logger that writes to l.
+func New(l *slog.Logger) *Adapter {
+	return &Adapter{Logger: l}
+}
+
+// Log implements godbc.Logger.
+func (a *Adapter) Log(kind godbc.Kind, frame runtime.Frame, message []interface{}) {
+	attrs := []any{
+		slog.String("kind", kind.String()),
+		slog.String("func", frame.Function),
+		slog.String("file", frame.File),
+		slog.Int("line", frame.Line),
+		slog.Uint64("pc", uint64(frame.PC)),
+	}
+
+	if kind == godbc.KindInvariant && len(message) > 0 {
+		attrs = append(attrs, slog.String("object", fmt.Sprintf("%+v", message[len(message)-1])))
+		message = message[:len(message)-1]
+	}
+	if len(message) > 0 {
+		attrs = append(attrs, slog.Any("msg", message))
+	}
+
+	a.Logger.Error("contract violation", attrs...)
+}
@@ -0,0 +1,43 @@
+// Package zerolog adapts godbc.Logger to github.com/rs/zerolog, so
+// contract violations are emitted as one structured event instead of
+// only appearing in the panic message.
+package zerolog
+
+import (
+	"fmt"
+	"runtime"
+
+	rszerolog "github.com/rs/zerolog"
+	"github.com/santosh653/godbc"
+)
+
+// Adapter implements godbc.Logger by writing one zerolog event per
+// contract violation.
+type Adapter struct {
+	Logger rszerolog.Logger
+}
+
+// New returns a godbc.Logger that writes to l.
+func New(l rszerolog.Logger) *Adapter {
+	return &Adapter{Logger: l}
+}
+
+// Log implements godbc.Logger.
+func (a *Adapter) Log(kind godbc.Kind, frame runtime.Frame, message []interface{}) {
+	event := a.Logger.Error().
+		Str("kind", kind.String()).
+		Str("func", frame.Function).
+		Str("file", frame.File).
+		Int("line", frame.Line).
+		Uint64("pc", uint64(frame.PC))
+
+	if kind == godbc.KindInvariant && len(message) > 0 {
+		event = event.Str("object", fmt.Sprintf("%+v", message[len(message)-1]))
+		message = message[:len(message)-1]
+	}
+	if len(message) > 0 {
+		event = event.Interface("msg", message)
+	}
+
+	event.Msg("contract violation")
+}
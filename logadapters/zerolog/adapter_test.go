@@ -0,0 +1,30 @@
+package zerolog
+
+import (
+	"bytes"
+	"testing"
+
+	rszerolog "github.com/rs/zerolog"
+	"github.com/santosh653/godbc"
+	"github.com/stretchr/testify/assert"
+)
+
+type invariantObj struct{ N int }
+
+func (o invariantObj) Invariant() bool { return o.N > 0 }
+func (o invariantObj) String() string  { return "invariantObj" }
+
+func TestAdapterLog(t *testing.T) {
+	var buf bytes.Buffer
+	godbc.SetLogger(New(rszerolog.New(&buf)))
+	defer godbc.SetLogger(nil)
+
+	assert.Panics(t, func() {
+		godbc.Invariant(invariantObj{N: 0}, "must be positive")
+	})
+
+	out := buf.String()
+	assert.Contains(t, out, `"kind":"INVARIANT"`)
+	assert.Contains(t, out, `"object":"invariantObj"`)
+	assert.Contains(t, out, "contract violation")
+}
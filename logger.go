@@ -0,0 +1,22 @@
+package godbc
+
+import "runtime"
+
+// Logger receives one event for every contract violation, in addition
+// to (not instead of) the usual panic/handler behavior. Install one with
+// SetLogger to route violations into a program's own structured
+// logging, e.g. via godbc/logadapters/zerolog or godbc/logadapters/slog.
+type Logger interface {
+	Log(kind Kind, frame runtime.Frame, message []interface{})
+}
+
+// logger, when non-nil, is notified of every contract violation. The
+// default (nil) logs nothing, leaving the existing stderr-formatted
+// panic as the only output, for backward compatibility.
+var logger Logger
+
+// SetLogger installs l to be notified of every contract violation.
+// Passing nil disables logging.
+func SetLogger(l Logger) {
+	logger = l
+}
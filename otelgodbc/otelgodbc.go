@@ -0,0 +1,81 @@
+// Package otelgodbc adds OpenTelemetry span events to godbc contract
+// failures.
+//
+// Importing this package does not change the behavior of
+// godbc.Require/Ensure/Check/Invariant. It instead exposes a
+// context-aware variant of each — RequireCtx, EnsureCtx, CheckCtx and
+// InvariantCtx — that records the violation as an error event on ctx's
+// active span, marks that span as failed, and then lets the usual panic
+// continue.
+//
+// RequireCtx and friends detect a violation by recovering the panic
+// godbc.Require/Ensure/Check/Invariant raise by default. If the program
+// has installed its own godbc.SetHandler, those functions return
+// normally instead of panicking, and no span event is recorded; this
+// package does not touch the global handler to work around that; since
+// godbc.SetHandler has no synchronization of its own, doing so would
+// make every RequireCtx/EnsureCtx/CheckCtx/InvariantCtx call race with
+// any other goroutine's contract checks.
+package otelgodbc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/santosh653/godbc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequireCtx is godbc.Require, plus a span event on ctx's active span
+// when the precondition fails.
+func RequireCtx(ctx context.Context, b bool, message ...interface{}) {
+	record(ctx, func() { godbc.Require(b, message...) })
+}
+
+// EnsureCtx is godbc.Ensure, plus a span event on ctx's active span
+// when the postcondition fails.
+func EnsureCtx(ctx context.Context, b bool, message ...interface{}) {
+	record(ctx, func() { godbc.Ensure(b, message...) })
+}
+
+// CheckCtx is godbc.Check, plus a span event on ctx's active span when
+// the assertion fails.
+func CheckCtx(ctx context.Context, b bool, message ...interface{}) {
+	record(ctx, func() { godbc.Check(b, message...) })
+}
+
+// InvariantCtx is godbc.Invariant, plus a span event on ctx's active
+// span when the invariant fails.
+func InvariantCtx(ctx context.Context, obj godbc.InvariantTester, message ...interface{}) {
+	record(ctx, func() { godbc.Invariant(obj, message...) })
+}
+
+// record runs fn and, if it panics with a *godbc.ContractError, records
+// the violation on ctx's active span before re-raising the same panic so
+// callers (and test frameworks) see the usual behavior.
+func record(ctx context.Context, fn func()) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		ce, ok := r.(*godbc.ContractError)
+		if !ok {
+			panic(r)
+		}
+
+		span := trace.SpanFromContext(ctx)
+		span.RecordError(ce, trace.WithAttributes(
+			attribute.String("godbc.kind", strings.ToLower(ce.Kind.String())),
+			attribute.String("godbc.func", ce.Func),
+			attribute.String("godbc.file", ce.File),
+			attribute.Int("godbc.line", ce.Line),
+		))
+		span.SetStatus(codes.Error, ce.Error())
+
+		panic(ce)
+	}()
+	fn()
+}
@@ -0,0 +1,41 @@
+package otelgodbc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRequireCtxRecordsSpanEvent(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	ctx, span := tp.Tracer("otelgodbc_test").Start(context.Background(), "op")
+
+	assert.Panics(t, func() {
+		RequireCtx(ctx, false, "boom")
+	})
+	span.End()
+
+	spans := sr.Ended()
+	if !assert.Len(t, spans, 1) {
+		return
+	}
+
+	events := spans[0].Events()
+	if !assert.Len(t, events, 1) {
+		return
+	}
+	assert.Equal(t, "exception", events[0].Name)
+
+	var gotKind bool
+	for _, attr := range events[0].Attributes {
+		if string(attr.Key) == "godbc.kind" {
+			gotKind = true
+			assert.Equal(t, "require", attr.Value.AsString())
+		}
+	}
+	assert.True(t, gotKind, "expected a godbc.kind attribute on the recorded event")
+}